@@ -0,0 +1,183 @@
+/**
+*  @file
+*  @copyright defined in scan-api/LICENSE
+ */
+
+// Package abi decodes contract logs against a user-supplied Ethereum-style
+// ABI, so scan-api can index token transfers and other contract events
+// instead of just plain transfers.
+//
+// Only fixed-size ABI types (address, bool, intN/uintN, bytesN) are
+// supported; dynamic types (string, bytes, arrays) require tail decoding
+// that this package does not yet implement.
+package abi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/joesixpack/scan-api/rpc"
+)
+
+// DecodedEvent is a contract log decoded against a known ABI event.
+type DecodedEvent struct {
+	Name        string
+	Address     string
+	BlockHeight uint64
+	TxHash      string
+	LogIndex    uint
+	Values      map[string]interface{}
+}
+
+type eventInput struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"indexed"`
+}
+
+type abiEntry struct {
+	Type   string       `json:"type"`
+	Name   string       `json:"name"`
+	Inputs []eventInput `json:"inputs"`
+}
+
+// DecodeLogs decodes every log in logs whose first topic matches a known
+// event signature in abiJSON. Logs that don't match any event in the ABI
+// are silently skipped.
+func DecodeLogs(abiJSON string, logs []rpc.Log) ([]DecodedEvent, error) {
+	var entries []abiEntry
+	if err := json.Unmarshal([]byte(abiJSON), &entries); err != nil {
+		return nil, fmt.Errorf("abi: parsing ABI: %v", err)
+	}
+
+	events := make(map[string]abiEntry)
+	for _, entry := range entries {
+		if entry.Type != "event" {
+			continue
+		}
+		events[eventSignatureHash(entry)] = entry
+	}
+
+	var decoded []DecodedEvent
+	for _, log := range logs {
+		if len(log.Topics) == 0 {
+			continue
+		}
+		entry, ok := events[strings.ToLower(log.Topics[0])]
+		if !ok {
+			continue
+		}
+
+		values, err := decodeEventValues(entry, log)
+		if err != nil {
+			return nil, fmt.Errorf("abi: decoding event %s: %v", entry.Name, err)
+		}
+
+		decoded = append(decoded, DecodedEvent{
+			Name:        entry.Name,
+			Address:     log.Address,
+			BlockHeight: log.BlockHeight,
+			TxHash:      log.TxHash,
+			LogIndex:    log.Index,
+			Values:      values,
+		})
+	}
+	return decoded, nil
+}
+
+// eventSignatureHash computes the keccak256 topic0 for entry, e.g.
+// keccak256("Transfer(address,address,uint256)").
+func eventSignatureHash(entry abiEntry) string {
+	types := make([]string, len(entry.Inputs))
+	for i, input := range entry.Inputs {
+		types[i] = input.Type
+	}
+
+	sig := entry.Name + "(" + strings.Join(types, ",") + ")"
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(sig))
+	return "0x" + hex.EncodeToString(h.Sum(nil))
+}
+
+// decodeEventValues decodes a log's indexed topics and non-indexed data
+// into a map keyed by ABI input name.
+func decodeEventValues(entry abiEntry, log rpc.Log) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(entry.Inputs))
+
+	topicIdx := 1 // topics[0] is the event signature
+	var nonIndexed []eventInput
+	for _, input := range entry.Inputs {
+		if !input.Indexed {
+			nonIndexed = append(nonIndexed, input)
+			continue
+		}
+		if topicIdx >= len(log.Topics) {
+			return nil, fmt.Errorf("missing topic for indexed field %q", input.Name)
+		}
+		word, err := decodeHexWord(log.Topics[topicIdx])
+		if err != nil {
+			return nil, err
+		}
+		value, err := decodeWord(input.Type, word)
+		if err != nil {
+			return nil, err
+		}
+		values[input.Name] = value
+		topicIdx++
+	}
+
+	for i, input := range nonIndexed {
+		start := i * 32
+		if start+32 > len(log.Data) {
+			return nil, fmt.Errorf("log data too short for field %q", input.Name)
+		}
+		value, err := decodeWord(input.Type, log.Data[start:start+32])
+		if err != nil {
+			return nil, err
+		}
+		values[input.Name] = value
+	}
+
+	return values, nil
+}
+
+func decodeHexWord(topic string) ([]byte, error) {
+	word, err := hex.DecodeString(strings.TrimPrefix(topic, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(word) != 32 {
+		return nil, fmt.Errorf("topic %q is not a 32-byte word", topic)
+	}
+	return word, nil
+}
+
+// decodeWord decodes a single 32-byte ABI word as typ.
+func decodeWord(typ string, word []byte) (interface{}, error) {
+	switch {
+	case typ == "address":
+		return "0x" + hex.EncodeToString(word[12:]), nil
+	case typ == "bytes" || strings.Contains(typ, "["):
+		return nil, fmt.Errorf("unsupported ABI type %q", typ)
+	case typ == "bool":
+		return word[31] != 0, nil
+	case strings.HasPrefix(typ, "uint"):
+		return new(big.Int).SetBytes(word), nil
+	case strings.HasPrefix(typ, "int"):
+		// intN words are two's complement over the full 256 bits.
+		v := new(big.Int).SetBytes(word)
+		if word[0]&0x80 != 0 {
+			v.Sub(v, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		return v, nil
+	case strings.HasPrefix(typ, "bytes"):
+		return word, nil
+	default:
+		return nil, fmt.Errorf("unsupported ABI type %q", typ)
+	}
+}
@@ -0,0 +1,124 @@
+/**
+*  @file
+*  @copyright defined in scan-api/LICENSE
+ */
+
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// BatchRequest is a single call to pack into a BatchCall.
+type BatchRequest struct {
+	Method string
+	Params interface{}
+}
+
+// BatchResponse is the result of a single call within a BatchCall. Result
+// is nil if Error is set.
+type BatchResponse struct {
+	Result json.RawMessage
+	Error  error
+}
+
+// BatchCall packs reqs into a single JSON-RPC batch request/response round
+// trip, instead of one round trip per call. Responses are returned in the
+// same order as reqs, regardless of the order the node replies in.
+func (rpc *SeeleRPC) BatchCall(reqs []BatchRequest) ([]BatchResponse, error) {
+	batch := make([]rpcRequest, len(reqs))
+	for i, req := range reqs {
+		var ps []interface{}
+		if req.Params != nil {
+			ps = []interface{}{req.Params}
+		}
+		batch[i] = rpcRequest{
+			JSONRPC: "2.0",
+			Method:  req.Method,
+			Params:  ps,
+			ID:      uint64(i + 1),
+		}
+	}
+
+	reqBody, err := json.Marshal(batch)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := rpc.doRequest(reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var rpcResps []rpcResponse
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&rpcResps); err != nil {
+		return nil, fmt.Errorf("decoding batch rpc response: %v", err)
+	}
+
+	byID := make(map[uint64]rpcResponse, len(rpcResps))
+	for _, resp := range rpcResps {
+		byID[resp.ID] = resp
+	}
+
+	results := make([]BatchResponse, len(reqs))
+	for i := range reqs {
+		resp, ok := byID[uint64(i+1)]
+		if !ok {
+			results[i] = BatchResponse{Error: fmt.Errorf("rpc: missing batch response for request %d (%s)", i, reqs[i].Method)}
+			continue
+		}
+		if resp.Error != nil {
+			results[i] = BatchResponse{Error: resp.Error}
+			continue
+		}
+		results[i] = BatchResponse{Result: resp.Result}
+	}
+	return results, nil
+}
+
+// GetBlocksByHeightRange fetches every block in [from, to] in a single
+// JSON-RPC batch round trip, instead of one GetBlockByHeight call per
+// block, which matters when backfilling thousands of blocks.
+func (rpc *SeeleRPC) GetBlocksByHeightRange(from, to uint64, fullTx bool) ([]*BlockInfo, error) {
+	if to < from {
+		return nil, fmt.Errorf("rpc: invalid height range [%d, %d]", from, to)
+	}
+
+	reqs := make([]BatchRequest, 0, to-from+1)
+	for h := from; h <= to; h++ {
+		reqs = append(reqs, BatchRequest{
+			Method: "seele.GetBlockByHeight",
+			Params: GetBlockByHeightRequest{Height: int64(h), FullTx: fullTx},
+		})
+	}
+
+	resps, err := rpc.BatchCall(reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*BlockInfo, len(resps))
+	for i, resp := range resps {
+		if resp.Error != nil {
+			return nil, fmt.Errorf("rpc: fetching block %d: %v", from+uint64(i), resp.Error)
+		}
+
+		var raw map[string]interface{}
+		decoder := json.NewDecoder(bytes.NewReader(resp.Result))
+		decoder.UseNumber()
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, err
+		}
+
+		block, err := parseBlockInfo(raw, fullTx)
+		if err != nil {
+			return nil, err
+		}
+		blocks[i] = block
+	}
+	return blocks, nil
+}
@@ -0,0 +1,173 @@
+/**
+*  @file
+*  @copyright defined in scan-api/LICENSE
+ */
+
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SeeleRPC is a JSON-RPC client for a single seele node.
+type SeeleRPC struct {
+	addr    string
+	client  *http.Client
+	retry   RetryPolicy
+	breaker *circuitBreaker
+}
+
+// Option configures a SeeleRPC client.
+type Option func(*SeeleRPC)
+
+// WithRetryPolicy overrides the default RetryPolicy used by rpc.call.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(rpc *SeeleRPC) { rpc.retry = p }
+}
+
+// WithCircuitBreaker overrides the default circuit breaker, which trips
+// after 5 consecutive failures and cools down for 30s.
+func WithCircuitBreaker(maxFailures int, cooldown time.Duration) Option {
+	return func(rpc *SeeleRPC) { rpc.breaker = newCircuitBreaker(maxFailures, cooldown) }
+}
+
+// NewSeeleRPC creates a SeeleRPC client talking to the node at addr.
+func NewSeeleRPC(addr string, opts ...Option) *SeeleRPC {
+	rpc := &SeeleRPC{
+		addr:    addr,
+		client:  &http.Client{},
+		retry:   DefaultRetryPolicy(),
+		breaker: newCircuitBreaker(5, 30*time.Second),
+	}
+	for _, opt := range opts {
+		opt(rpc)
+	}
+	return rpc
+}
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+	ID      uint64        `json:"id"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result"`
+	Error   *rpcError       `json:"error"`
+	ID      uint64          `json:"id"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+// call invokes method on the connected seele node and decodes the result
+// into out. The decoder is configured with UseNumber() so that large
+// on-chain values (balances, fees, difficulty) survive the round trip
+// without being rounded through float64.
+func (rpc *SeeleRPC) call(method string, params interface{}, out interface{}) error {
+	var ps []interface{}
+	if params != nil {
+		ps = []interface{}{params}
+	}
+
+	reqBody, err := json.Marshal(rpcRequest{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  ps,
+		ID:      1,
+	})
+	if err != nil {
+		return err
+	}
+
+	body, err := rpc.doRequest(reqBody)
+	if err != nil {
+		return err
+	}
+
+	var rpcResp rpcResponse
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&rpcResp); err != nil {
+		return fmt.Errorf("decoding rpc response for %s: %v", method, err)
+	}
+	if rpcResp.Error != nil {
+		return rpcResp.Error
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	resultDecoder := json.NewDecoder(bytes.NewReader(rpcResp.Result))
+	resultDecoder.UseNumber()
+	return resultDecoder.Decode(out)
+}
+
+// doRequest POSTs reqBody to the node and returns the raw response body,
+// retrying per rpc.retry on transport/5xx failures and short-circuiting
+// through rpc.breaker when the node has been failing consistently.
+func (rpc *SeeleRPC) doRequest(reqBody []byte) ([]byte, error) {
+	if !rpc.breaker.allow() {
+		return nil, fmt.Errorf("rpc: circuit breaker open for %s", rpc.addr)
+	}
+
+	policy := rpc.retry
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 1
+	}
+	if policy.Retryable == nil {
+		policy.Retryable = isRetryable
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < policy.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(policy.backoff(attempt - 1))
+		}
+
+		body, err := rpc.doRequestOnce(reqBody)
+		if err == nil {
+			rpc.breaker.recordSuccess()
+			return body, nil
+		}
+
+		lastErr = err
+		if !policy.Retryable(err) {
+			break
+		}
+	}
+
+	rpc.breaker.recordFailure()
+	return nil, lastErr
+}
+
+func (rpc *SeeleRPC) doRequestOnce(reqBody []byte) ([]byte, error) {
+	resp, err := rpc.client.Post(rpc.addr, "application/json", bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &httpStatusError{StatusCode: resp.StatusCode}
+	}
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,117 @@
+/**
+*  @file
+*  @copyright defined in scan-api/LICENSE
+ */
+
+// Package gasprice implements a fee oracle over recent blocks, similar in
+// spirit to go-ethereum's GPO, so that scan-api can surface a recommended
+// fee for wallets without requiring node-side support.
+package gasprice
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/joesixpack/scan-api/rpc"
+)
+
+// Config holds the tunable parameters for an Oracle.
+type Config struct {
+	Blocks     int
+	Percentile int
+	MaxPrice   *big.Int
+	Default    *big.Int
+}
+
+// Oracle suggests a fee to pay based on the fees paid in recent blocks.
+type Oracle struct {
+	Blocks     int
+	Percentile int
+	MaxPrice   *big.Int
+	Default    *big.Int
+
+	rpc *rpc.SeeleRPC
+
+	mu        sync.Mutex
+	lastHead  string
+	lastPrice *big.Int
+}
+
+// NewOracle creates a fee Oracle that samples cfg.Blocks recent blocks
+// through rpc.
+func NewOracle(rpc *rpc.SeeleRPC, cfg Config) *Oracle {
+	o := &Oracle{
+		rpc:        rpc,
+		Blocks:     cfg.Blocks,
+		Percentile: cfg.Percentile,
+		MaxPrice:   cfg.MaxPrice,
+		Default:    cfg.Default,
+	}
+	if o.Blocks <= 0 {
+		o.Blocks = 20
+	}
+	if o.Percentile < 0 {
+		o.Percentile = 0
+	} else if o.Percentile > 100 {
+		o.Percentile = 100
+	}
+	if o.Default == nil {
+		o.Default = big.NewInt(1)
+	}
+	return o
+}
+
+// SuggestFee returns a suggested fee at the Oracle's configured percentile
+// of fees paid in the last Blocks blocks, clamped to MaxPrice. Repeated
+// calls within the same block head reuse the previous computation.
+func (o *Oracle) SuggestFee(ctx context.Context) (*big.Int, error) {
+	current, err := o.rpc.CurrentBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	o.mu.Lock()
+	if o.lastPrice != nil && current.HeadHash == o.lastHead {
+		price := o.lastPrice
+		o.mu.Unlock()
+		return price, nil
+	}
+	o.mu.Unlock()
+
+	var fees []*big.Int
+	for sampled, height := 0, current.Height; sampled < o.Blocks && height > 0; height-- {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		block, err := o.rpc.GetBlockByHeight(height, true)
+		if err != nil {
+			return nil, err
+		}
+		for _, tx := range block.Txs {
+			fees = append(fees, tx.Fee)
+		}
+		sampled++
+	}
+
+	price := o.Default
+	if len(fees) > 0 {
+		sort.Slice(fees, func(i, j int) bool { return fees[i].Cmp(fees[j]) < 0 })
+		idx := (len(fees) - 1) * o.Percentile / 100
+		price = fees[idx]
+	}
+	if o.MaxPrice != nil && price.Cmp(o.MaxPrice) > 0 {
+		price = o.MaxPrice
+	}
+
+	o.mu.Lock()
+	o.lastHead = current.HeadHash
+	o.lastPrice = price
+	o.mu.Unlock()
+
+	return price, nil
+}
@@ -0,0 +1,55 @@
+/**
+*  @file
+*  @copyright defined in scan-api/LICENSE
+ */
+
+// Package jsonx converts JSON-RPC numeric fields decoded with
+// json.Number (see SeeleRPC.call, which calls UseNumber() on the
+// decoder) into Go's fixed-width and arbitrary-precision integer types.
+package jsonx
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// ToBigInt converts a decoded JSON number field into a *big.Int.
+func ToBigInt(v interface{}) (*big.Int, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return nil, fmt.Errorf("jsonx: value %v is not a json.Number", v)
+	}
+
+	i, ok := new(big.Int).SetString(n.String(), 10)
+	if !ok {
+		return nil, fmt.Errorf("jsonx: cannot parse %q as an integer", n.String())
+	}
+	return i, nil
+}
+
+// ToUint64 converts a decoded JSON number field into a uint64, returning
+// an error if the value is negative or exceeds the uint64 range.
+func ToUint64(v interface{}) (uint64, error) {
+	i, err := ToBigInt(v)
+	if err != nil {
+		return 0, err
+	}
+	if i.Sign() < 0 || !i.IsUint64() {
+		return 0, fmt.Errorf("jsonx: %s is out of range for uint64", i.String())
+	}
+	return i.Uint64(), nil
+}
+
+// ToInt64 converts a decoded JSON number field into an int64, returning
+// an error if the value exceeds the int64 range.
+func ToInt64(v interface{}) (int64, error) {
+	i, err := ToBigInt(v)
+	if err != nil {
+		return 0, err
+	}
+	if !i.IsInt64() {
+		return 0, fmt.Errorf("jsonx: %s is out of range for int64", i.String())
+	}
+	return i.Int64(), nil
+}
@@ -0,0 +1,110 @@
+/**
+*  @file
+*  @copyright defined in scan-api/LICENSE
+ */
+
+package rpc
+
+import (
+	"math/rand"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RetryPolicy configures how rpc.call retries a failed request.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	MaxAttempts int
+	// InitialDelay is the backoff before the first retry.
+	InitialDelay time.Duration
+	// MaxDelay caps the backoff between retries.
+	MaxDelay time.Duration
+	// Retryable reports whether err is worth retrying. Defaults to
+	// retrying transport errors and 5xx responses.
+	Retryable func(err error) bool
+}
+
+// DefaultRetryPolicy retries transport-level failures and 5xx responses
+// up to twice, with exponential backoff and jitter.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:  3,
+		InitialDelay: 200 * time.Millisecond,
+		MaxDelay:     2 * time.Second,
+		Retryable:    isRetryable,
+	}
+}
+
+// httpStatusError is returned by doRequest when the node responds with a
+// non-2xx HTTP status.
+type httpStatusError struct {
+	StatusCode int
+}
+
+func (e *httpStatusError) Error() string {
+	return "rpc: unexpected http status " + http.StatusText(e.StatusCode)
+}
+
+func isRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if _, ok := err.(net.Error); ok {
+		return true
+	}
+	if statusErr, ok := err.(*httpStatusError); ok {
+		return statusErr.StatusCode >= 500
+	}
+	return false
+}
+
+// backoff returns the delay before attempt n (0-indexed, n=0 is the delay
+// before the first retry), with full jitter in [0, delay].
+func (p RetryPolicy) backoff(n int) time.Duration {
+	delay := p.InitialDelay << uint(n)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+// circuitBreaker trips after maxFailures consecutive failures and
+// short-circuits calls for cooldown, so a down node doesn't leave every
+// caller waiting out a full retry budget.
+type circuitBreaker struct {
+	maxFailures int
+	cooldown    time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+func newCircuitBreaker(maxFailures int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{maxFailures: maxFailures, cooldown: cooldown}
+}
+
+// allow reports whether a call may proceed, i.e. the breaker isn't open.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.openUntil.IsZero() || time.Now().After(b.openUntil)
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.openUntil = time.Time{}
+}
+
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.maxFailures > 0 && b.failures >= b.maxFailures {
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
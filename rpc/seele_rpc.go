@@ -6,8 +6,12 @@
 package rpc
 
 import (
+	"encoding/hex"
 	"fmt"
 	"math/big"
+	"strings"
+
+	"github.com/joesixpack/scan-api/rpc/jsonx"
 )
 
 // CurrentBlock returns the current block info.
@@ -21,19 +25,28 @@ func (rpc *SeeleRPC) CurrentBlock() (currentBlock *CurrentBlock, err error) {
 		return nil, err
 	}
 
-	timestamp := int64(rpcOutputBlock["timestamp"].(float64))
-	difficulty := int64(rpcOutputBlock["difficulty"].(float64))
-	height := uint64(rpcOutputBlock["height"].(float64))
+	timestamp, err := jsonx.ToBigInt(rpcOutputBlock["timestamp"])
+	if err != nil {
+		return nil, err
+	}
+	difficulty, err := jsonx.ToBigInt(rpcOutputBlock["difficulty"])
+	if err != nil {
+		return nil, err
+	}
+	height, err := jsonx.ToUint64(rpcOutputBlock["height"])
+	if err != nil {
+		return nil, err
+	}
 
 	currentBlock = &CurrentBlock{
 		HeadHash:  rpcOutputBlock["hash"].(string),
 		Height:    height,
-		Timestamp: big.NewInt(timestamp),
-		Difficult: big.NewInt(difficulty),
+		Timestamp: timestamp,
+		Difficult: difficulty,
 		Creator:   rpcOutputBlock["creator"].(string),
 		TxCount:   len(rpcOutputBlock["transactions"].([]interface{})),
 	}
-	return currentBlock, err
+	return currentBlock, nil
 }
 
 //GetBlockByHeight get block and transaction data from seele node
@@ -47,38 +60,55 @@ func (rpc *SeeleRPC) GetBlockByHeight(h uint64, fullTx bool) (block *BlockInfo,
 		return nil, err
 	}
 
-	height := uint64(rpcOutputBlock["height"].(float64))
+	block, err = parseBlockInfo(rpcOutputBlock, fullTx)
+	if err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// parseBlockInfo converts a raw seele.GetBlockByHeight result, decoded with
+// json.Number, into a BlockInfo.
+func parseBlockInfo(rpcOutputBlock map[string]interface{}, fullTx bool) (*BlockInfo, error) {
+	height, err := jsonx.ToUint64(rpcOutputBlock["height"])
+	if err != nil {
+		return nil, err
+	}
 	hash := rpcOutputBlock["hash"].(string)
 	parentHash := rpcOutputBlock["parentHash"].(string)
-	nonce := uint64(rpcOutputBlock["nonce"].(float64))
+	nonce, err := jsonx.ToUint64(rpcOutputBlock["nonce"])
+	if err != nil {
+		return nil, err
+	}
 	stateHash := rpcOutputBlock["stateHash"].(string)
 	txHash := rpcOutputBlock["txHash"].(string)
 	creator := rpcOutputBlock["creator"].(string)
-	timestamp := int64(rpcOutputBlock["timestamp"].(float64))
-	difficulty := int64(rpcOutputBlock["difficulty"].(float64))
-	totalDifficulty := int64(rpcOutputBlock["totalDifficulty"].(float64))
+	timestamp, err := jsonx.ToBigInt(rpcOutputBlock["timestamp"])
+	if err != nil {
+		return nil, err
+	}
+	difficulty, err := jsonx.ToBigInt(rpcOutputBlock["difficulty"])
+	if err != nil {
+		return nil, err
+	}
+	totalDifficulty, err := jsonx.ToBigInt(rpcOutputBlock["totalDifficulty"])
+	if err != nil {
+		return nil, err
+	}
 
 	var Txs []Transaction
 	if fullTx {
-		var rpcTxs []interface{}
-		rpcTxs = rpcOutputBlock["transactions"].([]interface{})
+		rpcTxs := rpcOutputBlock["transactions"].([]interface{})
 		for i := 0; i < len(rpcTxs); i++ {
-			var tx Transaction
-			rpcTx := rpcTxs[i].(map[string]interface{})
-			tx.Hash = rpcTx["hash"].(string)
-			tx.From = rpcTx["from"].(string)
-			tx.To = rpcTx["to"].(string)
-			amount := int64(rpcTx["amount"].(float64))
-			tx.Amount = big.NewInt(amount)
-			tx.AccountNonce = uint64(rpcTx["accountNonce"].(float64))
-			tx.Payload = rpcTx["payload"].(string)
-			tx.Timestamp = uint64(rpcTx["timestamp"].(float64))
-			tx.Fee = int64(rpcTx["fee"].(float64))
-			Txs = append(Txs, tx)
+			tx, err := parseTransaction(rpcTxs[i].(map[string]interface{}))
+			if err != nil {
+				return nil, err
+			}
+			Txs = append(Txs, *tx)
 		}
 	}
 
-	block = &BlockInfo{
+	return &BlockInfo{
 		Height:          height,
 		Hash:            hash,
 		ParentHash:      parentHash,
@@ -86,12 +116,90 @@ func (rpc *SeeleRPC) GetBlockByHeight(h uint64, fullTx bool) (block *BlockInfo,
 		StateHash:       stateHash,
 		TxHash:          txHash,
 		Creator:         creator,
-		Timestamp:       big.NewInt(timestamp),
-		Difficulty:      big.NewInt(difficulty),
-		TotalDifficulty: big.NewInt(totalDifficulty),
+		Timestamp:       timestamp,
+		Difficulty:      difficulty,
+		TotalDifficulty: totalDifficulty,
 		Txs:             Txs,
+	}, nil
+}
+
+// parseTransaction converts a raw transaction, decoded with json.Number,
+// into a Transaction.
+func parseTransaction(rpcTx map[string]interface{}) (*Transaction, error) {
+	var tx Transaction
+	tx.Hash = rpcTx["hash"].(string)
+	tx.From = rpcTx["from"].(string)
+	tx.To = rpcTx["to"].(string)
+
+	amount, err := jsonx.ToBigInt(rpcTx["amount"])
+	if err != nil {
+		return nil, err
+	}
+	tx.Amount = amount
+
+	accountNonce, err := jsonx.ToUint64(rpcTx["accountNonce"])
+	if err != nil {
+		return nil, err
+	}
+	tx.AccountNonce = accountNonce
+
+	tx.Payload = rpcTx["payload"].(string)
+
+	timestamp, err := jsonx.ToUint64(rpcTx["timestamp"])
+	if err != nil {
+		return nil, err
+	}
+	tx.Timestamp = timestamp
+
+	fee, err := jsonx.ToBigInt(rpcTx["fee"])
+	if err != nil {
+		return nil, err
+	}
+	tx.Fee = fee
+
+	return &tx, nil
+}
+
+// parseLog converts a raw receipt log, decoded with json.Number, into a
+// Log. txHash is used as a fallback when the node doesn't echo it back
+// per-log.
+func parseLog(rawLog map[string]interface{}, txHash string) (*Log, error) {
+	var log Log
+	log.Address, _ = rawLog["address"].(string)
+
+	if rawTopics, ok := rawLog["topics"].([]interface{}); ok {
+		for _, rawTopic := range rawTopics {
+			log.Topics = append(log.Topics, rawTopic.(string))
+		}
+	}
+
+	data, _ := rawLog["data"].(string)
+	decoded, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("rpc: decoding log data %q: %v", data, err)
+	}
+	log.Data = decoded
+
+	if rawHeight, ok := rawLog["blockHeight"]; ok {
+		height, err := jsonx.ToUint64(rawHeight)
+		if err != nil {
+			return nil, err
+		}
+		log.BlockHeight = height
+	}
+
+	log.TxHash = txHash
+	if rawTxHash, ok := rawLog["txHash"].(string); ok && rawTxHash != "" {
+		log.TxHash = rawTxHash
 	}
-	return block, err
+
+	index, err := jsonx.ToUint64(rawLog["index"])
+	if err != nil {
+		return nil, err
+	}
+	log.Index = uint(index)
+
+	return &log, nil
 }
 
 // GetPeersInfo get peers info from connected seele node
@@ -135,14 +243,17 @@ func (rpc *SeeleRPC) GetPeersInfo() (result []PeerInfo, err error) {
 		rpcPeerNetWork := rpcPeerInfo["network"].(map[string]interface{})
 		localAddress := rpcPeerNetWork["localAddress"].(string)
 		remoteAddress := rpcPeerNetWork["remoteAddress"].(string)
-		shardNumber := int(rpcPeerInfo["shard"].(float64))
+		shardNumber, err := jsonx.ToInt64(rpcPeerInfo["shard"])
+		if err != nil {
+			return nil, err
+		}
 
 		peerInfo := PeerInfo{
 			ID:            id,
 			Caps:          caps,
 			LocalAddress:  localAddress,
 			RemoteAddress: remoteAddress,
-			ShardNumber:   shardNumber,
+			ShardNumber:   int(shardNumber),
 		}
 
 		peerInfos = append(peerInfos, peerInfo)
@@ -152,10 +263,10 @@ func (rpc *SeeleRPC) GetPeersInfo() (result []PeerInfo, err error) {
 }
 
 // GetBalance get the balance of the account
-func (rpc *SeeleRPC) GetBalance(address string) (int64, error) {
+func (rpc *SeeleRPC) GetBalance(address string) (*big.Int, error) {
 	result := make(map[string]interface{})
 	if err := rpc.call("seele_getBalance", &address, &result); err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	// result data struct:
@@ -165,12 +276,31 @@ func (rpc *SeeleRPC) GetBalance(address string) (int64, error) {
 	// ]
 	account := result["Account"].(string)
 	if account != address {
-		return 0, fmt.Errorf("expected balance '%s', actually '%s'", address, result)
+		return nil, fmt.Errorf("expected balance '%s', actually '%s'", address, result)
+	}
+	balance, err := jsonx.ToBigInt(result["Balance"])
+	if err != nil {
+		return nil, err
 	}
-	balance := int64(result["Balance"].(float64))
 	return balance, nil
 }
 
+// GetBalanceInt64 is a migration shim for callers that have not yet moved
+// to the arbitrary-precision GetBalance. It fails if the balance no longer
+// fits in an int64.
+//
+// Deprecated: use GetBalance.
+func (rpc *SeeleRPC) GetBalanceInt64(address string) (int64, error) {
+	balance, err := rpc.GetBalance(address)
+	if err != nil {
+		return 0, err
+	}
+	if !balance.IsInt64() {
+		return 0, fmt.Errorf("balance %s no longer fits in an int64", balance.String())
+	}
+	return balance.Int64(), nil
+}
+
 // GetReceiptByTxHash get the receipt by tx hash
 func (rpc *SeeleRPC) GetReceiptByTxHash(txhash string) (*Receipt, error) {
 	rpcOutputReceipt := make(map[string]interface{})
@@ -187,14 +317,39 @@ func (rpc *SeeleRPC) GetReceiptByTxHash(txhash string) (*Receipt, error) {
 	//   usedGas:0
 	//   contract:0x
 	//   failed:false
+	//   logs:[
+	//     map[
+	//       address:0x0ea2a45ab5a909c309439b0e004c61b7b2a3e831
+	//       topics:[0xddf252ad...]
+	//       data:0x0000000000000000000000000000000000000000000000000000000000000001
+	//       index:0
+	//     ]
+	//   ]
 	// ]
 	result := rpcOutputReceipt["result"].(string)
 	postState := rpcOutputReceipt["poststate"].(string)
 	txHash := rpcOutputReceipt["txhash"].(string)
 	contractAddress := rpcOutputReceipt["contract"].(string)
 	failed := rpcOutputReceipt["failed"].(bool)
-	totalFee := int64(rpcOutputReceipt["totalFee"].(float64))
-	usedGas := int64(rpcOutputReceipt["usedGas"].(float64))
+	totalFee, err := jsonx.ToBigInt(rpcOutputReceipt["totalFee"])
+	if err != nil {
+		return nil, err
+	}
+	usedGas, err := jsonx.ToBigInt(rpcOutputReceipt["usedGas"])
+	if err != nil {
+		return nil, err
+	}
+
+	var logs []Log
+	if rawLogs, ok := rpcOutputReceipt["logs"].([]interface{}); ok {
+		for _, rawLog := range rawLogs {
+			log, err := parseLog(rawLog.(map[string]interface{}), txHash)
+			if err != nil {
+				return nil, err
+			}
+			logs = append(logs, *log)
+		}
+	}
 
 	receipt := Receipt{
 		Result:          result,
@@ -202,8 +357,9 @@ func (rpc *SeeleRPC) GetReceiptByTxHash(txhash string) (*Receipt, error) {
 		TxHash:          txHash,
 		ContractAddress: contractAddress,
 		Failed:          failed,
-		TotalFee:        big.NewInt(totalFee),
-		UsedGas:         big.NewInt(usedGas),
+		TotalFee:        totalFee,
+		UsedGas:         usedGas,
+		Logs:            logs,
 	}
 	return &receipt, nil
 }
@@ -228,17 +384,11 @@ func (rpc *SeeleRPC) GetPendingTransactions() ([]Transaction, error) {
 	// ]
 	var Txs []Transaction
 	for _, rpcTx := range rpcOutputTxs {
-		var tx Transaction
-		tx.Hash = rpcTx["hash"].(string)
-		tx.From = rpcTx["from"].(string)
-		tx.To = rpcTx["to"].(string)
-		amount := int64(rpcTx["amount"].(float64))
-		tx.Amount = big.NewInt(amount)
-		tx.AccountNonce = uint64(rpcTx["accountNonce"].(float64))
-		tx.Payload = rpcTx["payload"].(string)
-		tx.Timestamp = uint64(rpcTx["timestamp"].(float64))
-		tx.Fee = int64(rpcTx["fee"].(float64))
-		Txs = append(Txs, tx)
+		tx, err := parseTransaction(rpcTx)
+		if err != nil {
+			return nil, err
+		}
+		Txs = append(Txs, *tx)
 	}
 	return Txs, nil
 }
@@ -0,0 +1,154 @@
+/**
+*  @file
+*  @copyright defined in scan-api/LICENSE
+ */
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// defaultShardWorkers bounds how many shard calls a ShardedClient runs
+// concurrently.
+const defaultShardWorkers = 8
+
+// ShardedClient fans calls out across one SeeleRPC per shard, since a
+// Seele network is sharded and a single SeeleRPC only talks to one shard's
+// node.
+type ShardedClient struct {
+	clients map[int]*SeeleRPC
+	workers int
+}
+
+// NewShardedClient creates a ShardedClient with one SeeleRPC per endpoint,
+// keyed by shard number.
+func NewShardedClient(endpoints map[int]string) *ShardedClient {
+	clients := make(map[int]*SeeleRPC, len(endpoints))
+	for shard, addr := range endpoints {
+		clients[shard] = NewSeeleRPC(addr)
+	}
+	return &ShardedClient{
+		clients: clients,
+		workers: defaultShardWorkers,
+	}
+}
+
+// MultiError aggregates per-shard errors from a fan-out call. A shard not
+// present in the map succeeded.
+type MultiError map[int]error
+
+func (e MultiError) Error() string {
+	msg := fmt.Sprintf("%d shard(s) failed:", len(e))
+	for shard, err := range e {
+		msg += fmt.Sprintf(" [shard %d: %v]", shard, err)
+	}
+	return msg
+}
+
+// forEachShard runs fn for every shard client concurrently, bounded by
+// ShardedClient.workers, and aggregates failures into a MultiError. fn is
+// responsible for recording its own shard's result.
+func (c *ShardedClient) forEachShard(ctx context.Context, fn func(shard int, client *SeeleRPC) error) MultiError {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs = make(MultiError)
+		sem  = make(chan struct{}, c.workers)
+	)
+
+	for shard, client := range c.clients {
+		wg.Add(1)
+		go func(shard int, client *SeeleRPC) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				mu.Lock()
+				errs[shard] = ctx.Err()
+				mu.Unlock()
+				return
+			}
+			defer func() { <-sem }()
+
+			if err := fn(shard, client); err != nil {
+				mu.Lock()
+				errs[shard] = err
+				mu.Unlock()
+			}
+		}(shard, client)
+	}
+
+	wg.Wait()
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// CurrentBlocks returns the current block from every shard, keyed by
+// shard number. Shards that failed are omitted; inspect the returned
+// MultiError for details.
+func (c *ShardedClient) CurrentBlocks(ctx context.Context) (map[int]*CurrentBlock, MultiError) {
+	var mu sync.Mutex
+	result := make(map[int]*CurrentBlock, len(c.clients))
+
+	errs := c.forEachShard(ctx, func(shard int, client *SeeleRPC) error {
+		block, err := client.CurrentBlock()
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		result[shard] = block
+		mu.Unlock()
+		return nil
+	})
+
+	return result, errs
+}
+
+// GetBalanceAllShards returns the balance of addr on every shard, keyed by
+// shard number. Shards that failed are omitted; inspect the returned
+// MultiError for details.
+func (c *ShardedClient) GetBalanceAllShards(ctx context.Context, addr string) (map[int]*big.Int, MultiError) {
+	var mu sync.Mutex
+	result := make(map[int]*big.Int, len(c.clients))
+
+	errs := c.forEachShard(ctx, func(shard int, client *SeeleRPC) error {
+		balance, err := client.GetBalance(addr)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		result[shard] = balance
+		mu.Unlock()
+		return nil
+	})
+
+	return result, errs
+}
+
+// GetBlockByHeightAll returns block h from every shard, keyed by shard
+// number. Shards that failed are omitted; inspect the returned MultiError
+// for details.
+func (c *ShardedClient) GetBlockByHeightAll(ctx context.Context, h uint64, fullTx bool) (map[int]*BlockInfo, MultiError) {
+	var mu sync.Mutex
+	result := make(map[int]*BlockInfo, len(c.clients))
+
+	errs := c.forEachShard(ctx, func(shard int, client *SeeleRPC) error {
+		block, err := client.GetBlockByHeight(h, fullTx)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		result[shard] = block
+		mu.Unlock()
+		return nil
+	})
+
+	return result, errs
+}
@@ -0,0 +1,223 @@
+/**
+*  @file
+*  @copyright defined in scan-api/LICENSE
+ */
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// EventType identifies the kind of ChainEvent emitted by Subscribe.
+type EventType int
+
+const (
+	// NewBlock indicates a new canonical block was appended to the chain.
+	NewBlock EventType = iota
+	// Revert indicates a previously emitted block was orphaned by a reorg.
+	Revert
+)
+
+// ChainEvent is emitted by Subscribe for every canonical block appended to,
+// or orphaned from, the chain.
+type ChainEvent struct {
+	Type  EventType
+	Block *BlockInfo
+}
+
+// defaultHeaderCacheSize is the default number of recent headers kept so
+// that reorgs shallower than this can be resolved without re-fetching
+// every orphaned block from the node.
+const defaultHeaderCacheSize = 128
+
+// defaultPollInterval is how often Subscribe polls the chain head.
+const defaultPollInterval = 3 * time.Second
+
+// SubscribeOption configures Subscribe.
+type SubscribeOption func(*subscribeConfig)
+
+type subscribeConfig struct {
+	headerCacheSize int
+	pollInterval    time.Duration
+}
+
+// WithHeaderCacheSize overrides the number of recent headers kept in
+// memory (default 128) for reorg detection.
+func WithHeaderCacheSize(n int) SubscribeOption {
+	return func(c *subscribeConfig) { c.headerCacheSize = n }
+}
+
+// WithPollInterval overrides how often Subscribe polls the chain head
+// (default 3s).
+func WithPollInterval(d time.Duration) SubscribeOption {
+	return func(c *subscribeConfig) { c.pollInterval = d }
+}
+
+// Subscribe polls the chain head starting at fromHeight and streams
+// ChainEvents on the returned channel: NewBlock for each block appended to
+// the canonical chain, and, when a reorg is detected, Revert for each
+// orphaned block (newest first) followed by NewBlock for the new
+// canonical chain. The channel is closed when ctx is done.
+func (rpc *SeeleRPC) Subscribe(ctx context.Context, fromHeight uint64, opts ...SubscribeOption) (<-chan ChainEvent, error) {
+	cfg := subscribeConfig{
+		headerCacheSize: defaultHeaderCacheSize,
+		pollInterval:    defaultPollInterval,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	events := make(chan ChainEvent)
+
+	go func() {
+		defer close(events)
+
+		recent := newHeaderCache(cfg.headerCacheSize)
+		next := fromHeight
+
+		ticker := time.NewTicker(cfg.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			head, err := rpc.CurrentBlock()
+			if err != nil {
+				continue
+			}
+
+			for next <= head.Height {
+				block, err := rpc.GetBlockByHeight(next, true)
+				if err != nil {
+					break
+				}
+
+				if last := recent.last(); last != nil && block.ParentHash != last.Hash {
+					reverted, newChain, err := rpc.resolveReorg(recent, block)
+					if err != nil {
+						break
+					}
+
+					for _, orphan := range reverted {
+						select {
+						case events <- ChainEvent{Type: Revert, Block: orphan}:
+						case <-ctx.Done():
+							return
+						}
+					}
+					for _, b := range newChain {
+						recent.push(b)
+						select {
+						case events <- ChainEvent{Type: NewBlock, Block: b}:
+						case <-ctx.Done():
+							return
+						}
+					}
+
+					next = newChain[len(newChain)-1].Height + 1
+					continue
+				}
+
+				recent.push(block)
+				select {
+				case events <- ChainEvent{Type: NewBlock, Block: block}:
+				case <-ctx.Done():
+					return
+				}
+				next++
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// resolveReorg walks the new chain backward from head until it finds an
+// ancestor already present in the cache, then returns the cached blocks
+// that must be reverted (newest first) and the new chain that should
+// replace them (oldest first, starting at the common ancestor's child).
+func (rpc *SeeleRPC) resolveReorg(cache *headerCache, head *BlockInfo) (reverted, newChain []*BlockInfo, err error) {
+	newChain = []*BlockInfo{head}
+
+	for steps := 0; ; steps++ {
+		if ancestor, ok := cache.blocks[head.Height-1]; ok && ancestor.Hash == head.ParentHash {
+			break
+		}
+		if head.Height == 0 || steps >= cache.size {
+			return nil, nil, fmt.Errorf("rpc: reorg deeper than header cache of %d blocks, no common ancestor found", cache.size)
+		}
+
+		head, err = rpc.GetBlockByHeight(head.Height-1, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		newChain = append([]*BlockInfo{head}, newChain...)
+	}
+
+	for cache.lastHeight() >= newChain[0].Height {
+		reverted = append(reverted, cache.popBack())
+	}
+
+	return reverted, newChain, nil
+}
+
+// headerCache keeps the last N block headers indexed by height so shallow
+// reorgs can be resolved without re-fetching orphaned blocks from the node.
+type headerCache struct {
+	size    int
+	heights []uint64
+	blocks  map[uint64]*BlockInfo
+}
+
+func newHeaderCache(size int) *headerCache {
+	if size <= 0 {
+		size = defaultHeaderCacheSize
+	}
+	return &headerCache{
+		size:   size,
+		blocks: make(map[uint64]*BlockInfo),
+	}
+}
+
+func (c *headerCache) push(block *BlockInfo) {
+	c.heights = append(c.heights, block.Height)
+	c.blocks[block.Height] = block
+	if len(c.heights) > c.size {
+		delete(c.blocks, c.heights[0])
+		c.heights = c.heights[1:]
+	}
+}
+
+// popBack drops and returns the most recently pushed block, e.g. once it
+// turns out to have been orphaned by a reorg.
+func (c *headerCache) popBack() *BlockInfo {
+	if len(c.heights) == 0 {
+		return nil
+	}
+	h := c.heights[len(c.heights)-1]
+	c.heights = c.heights[:len(c.heights)-1]
+	block := c.blocks[h]
+	delete(c.blocks, h)
+	return block
+}
+
+func (c *headerCache) last() *BlockInfo {
+	if len(c.heights) == 0 {
+		return nil
+	}
+	return c.blocks[c.heights[len(c.heights)-1]]
+}
+
+func (c *headerCache) lastHeight() uint64 {
+	if len(c.heights) == 0 {
+		return 0
+	}
+	return c.heights[len(c.heights)-1]
+}
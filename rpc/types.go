@@ -0,0 +1,82 @@
+/**
+*  @file
+*  @copyright defined in scan-api/LICENSE
+ */
+
+package rpc
+
+import "math/big"
+
+// GetBlockByHeightRequest is the request parameter for seele.GetBlockByHeight.
+type GetBlockByHeightRequest struct {
+	Height int64 `json:"height"`
+	FullTx bool  `json:"fullTx"`
+}
+
+// CurrentBlock holds the summary info of the current chain head.
+type CurrentBlock struct {
+	HeadHash  string
+	Height    uint64
+	Timestamp *big.Int
+	Difficult *big.Int
+	Creator   string
+	TxCount   int
+}
+
+// BlockInfo holds a full block as returned by the seele node.
+type BlockInfo struct {
+	Height          uint64
+	Hash            string
+	ParentHash      string
+	Nonce           uint64
+	StateHash       string
+	TxHash          string
+	Creator         string
+	Timestamp       *big.Int
+	Difficulty      *big.Int
+	TotalDifficulty *big.Int
+	Txs             []Transaction
+}
+
+// Transaction holds a single on-chain transaction.
+type Transaction struct {
+	Hash         string
+	From         string
+	To           string
+	Amount       *big.Int
+	AccountNonce uint64
+	Payload      string
+	Timestamp    uint64
+	Fee          *big.Int
+}
+
+// Receipt holds the execution result of a transaction.
+type Receipt struct {
+	Result          string
+	PostState       string
+	TxHash          string
+	ContractAddress string
+	Failed          bool
+	TotalFee        *big.Int
+	UsedGas         *big.Int
+	Logs            []Log
+}
+
+// Log is a single event emitted during a transaction's execution.
+type Log struct {
+	Address     string
+	Topics      []string
+	Data        []byte
+	BlockHeight uint64
+	TxHash      string
+	Index       uint
+}
+
+// PeerInfo describes a connected seele peer.
+type PeerInfo struct {
+	ID            string
+	Caps          []string
+	LocalAddress  string
+	RemoteAddress string
+	ShardNumber   int
+}